@@ -0,0 +1,324 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsa2022
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/piprate/json-gold/ld"
+	"github.com/trustbloc/did-go/doc/ld/processor"
+	"github.com/trustbloc/kms-go/doc/jose/jwk"
+	"github.com/trustbloc/vc-go/signature/kmscrypto"
+
+	"github.com/trustbloc/vc-go/dataintegrity/models"
+	"github.com/trustbloc/vc-go/dataintegrity/suite"
+	signatureverifier "github.com/trustbloc/vc-go/signature/verifier"
+)
+
+const (
+	// SuiteType "eddsa-rdfc-2022" is the data integrity Type identifier for the suite
+	// implementing EdDSA signatures with RDF Dataset Canonicalization as per this
+	// spec: https://www.w3.org/TR/vc-di-eddsa/#eddsa-rdfc-2022
+	SuiteType = "eddsa-rdfc-2022"
+)
+
+// SignerGetter returns a Signer, which must sign with the private key matching
+// the public key provided in models.ProofOptions.VerificationMethod.
+type SignerGetter func(pub *jwk.JWK) (Signer, error)
+
+// WithStaticSigner sets the Suite to use a fixed Signer, with externally-chosen signing key.
+//
+// Use when a signing Suite is initialized for a single signature, then thrown away.
+func WithStaticSigner(signer Signer) SignerGetter {
+	return func(*jwk.JWK) (Signer, error) {
+		return signer, nil
+	}
+}
+
+// WithKMSCryptoWrapper provides a SignerGetter using the kmscrypto wrapper.
+//
+// This SignerGetter assumes that the public key JWKs provided were received
+// from the same kmscrypto.KMSCrypto implementation.
+func WithKMSCryptoWrapper(kmsCrypto kmscrypto.KMSCryptoSigner) SignerGetter {
+	return func(pub *jwk.JWK) (Signer, error) {
+		return kmsCrypto.FixedKeySigner(pub)
+	}
+}
+
+// A Signer is able to sign messages.
+type Signer interface {
+	// Sign will sign msg using a private key internal to the Signer.
+	// returns:
+	// 		signature in []byte
+	//		error in case of errors
+	Sign(msg []byte) ([]byte, error)
+}
+
+// A Verifier is able to verify messages.
+type Verifier interface {
+	// Verify will verify a signature for the given msg using a matching signature primitive in kh key handle of
+	// a public key
+	// returns:
+	// 		error in case of errors or nil if signature verification was successful
+	Verify(pubKey *signatureverifier.PublicKey, msg, signature []byte) error
+}
+
+// Suite implements the eddsa-rdfc-2022 data integrity cryptographic suite.
+type Suite struct {
+	ldLoader     ld.DocumentLoader
+	verifier     Verifier
+	signerGetter SignerGetter
+}
+
+// Options provides initialization options for Suite.
+type Options struct {
+	LDDocumentLoader ld.DocumentLoader
+	Verifier         Verifier
+	SignerGetter     SignerGetter
+}
+
+// SuiteInitializer is the initializer for Suite.
+type SuiteInitializer func() (suite.Suite, error)
+
+// New constructs an initializer for Suite.
+func New(options *Options) SuiteInitializer {
+	return func() (suite.Suite, error) {
+		return &Suite{
+			ldLoader:     options.LDDocumentLoader,
+			verifier:     options.Verifier,
+			signerGetter: options.SignerGetter,
+		}, nil
+	}
+}
+
+type initializer SuiteInitializer
+
+// Signer private, implements suite.SignerInitializer.
+func (i initializer) Signer() (suite.Signer, error) {
+	return i()
+}
+
+// Verifier private, implements suite.VerifierInitializer.
+func (i initializer) Verifier() (suite.Verifier, error) {
+	return i()
+}
+
+// Type private, implements suite.SignerInitializer and
+// suite.VerifierInitializer.
+func (i initializer) Type() string {
+	return SuiteType
+}
+
+// SignerInitializerOptions provides options for a SignerInitializer.
+type SignerInitializerOptions struct {
+	LDDocumentLoader ld.DocumentLoader
+	SignerGetter     SignerGetter
+}
+
+// NewSignerInitializer returns a suite.SignerInitializer that initializes an eddsa-rdfc-2022
+// signing Suite with the given SignerInitializerOptions.
+func NewSignerInitializer(options *SignerInitializerOptions) suite.SignerInitializer {
+	return initializer(New(&Options{
+		LDDocumentLoader: options.LDDocumentLoader,
+		SignerGetter:     options.SignerGetter,
+	}))
+}
+
+// VerifierInitializerOptions provides options for a VerifierInitializer.
+type VerifierInitializerOptions struct {
+	LDDocumentLoader ld.DocumentLoader // required
+	Verifier         Verifier          // optional
+}
+
+// NewVerifierInitializer returns a suite.VerifierInitializer that initializes an
+// eddsa-rdfc-2022 verification Suite with the given VerifierInitializerOptions.
+func NewVerifierInitializer(options *VerifierInitializerOptions) suite.VerifierInitializer {
+	verifier := options.Verifier
+
+	if verifier == nil {
+		verifier = signatureverifier.NewEd25519SignatureVerifier()
+	}
+
+	return initializer(New(&Options{
+		LDDocumentLoader: options.LDDocumentLoader,
+		Verifier:         verifier,
+	}))
+}
+
+const (
+	ldCtxKey = "@context"
+)
+
+// CreateProof implements the eddsa-rdfc-2022 cryptographic suite for Add Proof:
+// https://www.w3.org/TR/vc-di-eddsa/#create-proof-eddsa-rdfc-2022
+func (s *Suite) CreateProof(doc []byte, opts *models.ProofOptions) (*models.Proof, error) {
+	if opts.VerificationMethod == nil {
+		return nil, errors.New("verification method is required")
+	}
+
+	p := &models.Proof{
+		Type:               models.DataIntegrityProof,
+		CryptoSuite:        SuiteType,
+		ProofPurpose:       opts.Purpose,
+		Domain:             opts.Domain,
+		Challenge:          opts.Challenge,
+		VerificationMethod: opts.VerificationMethod.ID,
+		Created:            opts.Created.Format(models.DateTimeFormat),
+	}
+
+	hashData, vmKey, err := s.transformAndHash(doc, p, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := sign(hashData, vmKey, s.signerGetter)
+	if err != nil {
+		return nil, err
+	}
+
+	sigStr, err := multibase.Encode(multibase.Base58BTC, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ProofValue = sigStr
+
+	return p, nil
+}
+
+// VerifyProof implements the eddsa-rdfc-2022 cryptographic suite for Verify Proof:
+// https://www.w3.org/TR/vc-di-eddsa/#verify-proof-eddsa-rdfc-2022
+func (s *Suite) VerifyProof(doc []byte, proof *models.Proof, opts *models.ProofOptions) error {
+	hashData, vmKey, err := s.transformAndHash(doc, proof, opts)
+	if err != nil {
+		return err
+	}
+
+	_, signature, err := multibase.Decode(proof.ProofValue)
+	if err != nil {
+		return fmt.Errorf("decoding proofValue: %w", err)
+	}
+
+	err = s.verifier.Verify(&signatureverifier.PublicKey{JWK: vmKey}, hashData, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify eddsa-rdfc-2022 DI proof: %w", err)
+	}
+
+	return nil
+}
+
+// RequiresCreated returns false, as the eddsa-rdfc-2022 cryptographic suite does not
+// require the use of the models.Proof.Created field.
+func (s *Suite) RequiresCreated() bool {
+	return false
+}
+
+// transformAndHash performs the transformation and hashing steps of the suite: both the
+// document and the proof configuration are canonicalized with RDFC-1.0 (URDNA2015), and
+// the resulting hash data is the SHA-256 hash of the proof configuration followed by the
+// SHA-256 hash of the document.
+func (s *Suite) transformAndHash(
+	doc []byte,
+	proof *models.Proof,
+	opts *models.ProofOptions,
+) ([]byte, *jwk.JWK, error) {
+	docData := make(map[string]interface{})
+
+	err := json.Unmarshal(doc, &docData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("eddsa-rdfc-2022 suite expects JSON-LD payload: %w", err)
+	}
+
+	if opts.VerificationMethod == nil {
+		return nil, nil, errors.New("verification method is required")
+	}
+
+	vmKey := opts.VerificationMethod.JSONWebKey()
+	if vmKey == nil {
+		return nil, nil, errors.New("verification method needs JWK")
+	}
+
+	if vmKey.Kty != "OKP" || vmKey.Crv != "Ed25519" {
+		return nil, nil, errors.New("unsupported EdDSA key: expected Ed25519")
+	}
+
+	if opts.ProofType != models.DataIntegrityProof || opts.SuiteType != SuiteType {
+		return nil, nil, suite.ErrProofTransformation
+	}
+
+	canonDoc, err := canonicalize(docData, s.ldLoader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	canonConf, err := canonicalize(proofConfig(docData[ldCtxKey], proof), s.ldLoader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hashData(canonDoc, canonConf), vmKey, nil
+}
+
+func canonicalize(data map[string]interface{}, loader ld.DocumentLoader) ([]byte, error) {
+	out, err := processor.Default().GetCanonicalDocument(data, processor.WithDocumentLoader(loader))
+	if err != nil {
+		return nil, fmt.Errorf("canonicalizing signature base data: %w", err)
+	}
+
+	return out, nil
+}
+
+func hashData(transformedDoc, proofConfig []byte) []byte {
+	proofConfigHash := sha256.Sum256(proofConfig)
+	transformedDocHash := sha256.Sum256(transformedDoc)
+
+	return append(proofConfigHash[:], transformedDocHash[:]...)
+}
+
+// proofConfig returns the proof configuration, which is the proof without its proofValue,
+// using the @context of the secured document.
+func proofConfig(docCtx interface{}, proof *models.Proof) map[string]interface{} {
+	conf := map[string]interface{}{
+		ldCtxKey:             docCtx,
+		"type":               models.DataIntegrityProof,
+		"cryptosuite":        SuiteType,
+		"verificationMethod": proof.VerificationMethod,
+		"proofPurpose":       proof.ProofPurpose,
+	}
+
+	if proof.Created != "" {
+		conf["created"] = proof.Created
+	}
+
+	if proof.Domain != "" {
+		conf["domain"] = proof.Domain
+	}
+
+	if proof.Challenge != "" {
+		conf["challenge"] = proof.Challenge
+	}
+
+	return conf
+}
+
+func sign(sigBase []byte, key *jwk.JWK, signerGetter SignerGetter) ([]byte, error) {
+	signer, err := signerGetter(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := signer.Sign(sigBase)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,439 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsa2022
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+	"github.com/trustbloc/did-go/doc/did"
+	ldcontext "github.com/trustbloc/did-go/doc/ld/context"
+	"github.com/trustbloc/did-go/doc/ld/documentloader"
+	mockldstore "github.com/trustbloc/did-go/doc/ld/mock"
+	"github.com/trustbloc/did-go/doc/ld/store"
+	"github.com/trustbloc/kms-go/doc/jose/jwk/jwksupport"
+
+	"github.com/trustbloc/vc-go/dataintegrity/models"
+	"github.com/trustbloc/vc-go/dataintegrity/suite"
+	"github.com/trustbloc/vc-go/internal/testutil/signatureutil"
+)
+
+var (
+	//go:embed testdata/alumni_credential.jsonld
+	alumniCredential []byte
+	//go:embed testdata/invalid_jsonld.jsonld
+	invalidJSONLD []byte
+	// credentialsV2Context holds the subset of the https://www.w3.org/ns/credentials/v2
+	// context terms that are used by the test credential and the proof configuration.
+	//go:embed testdata/credentials_v2.jsonld
+	credentialsV2Context []byte
+	//go:embed testdata/examples_v2.jsonld
+	examplesV2Context []byte
+)
+
+// Test vector from https://www.w3.org/TR/vc-di-eddsa/#representation-eddsa-rdfc-2022
+const (
+	vectorSecretKeyMultibase = "z3u2en7t5LR2WtQH5PfFqMqwVHBeXouLzo6haApm8XHqvjxq"
+	vectorPublicKeyMultibase = "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+	vectorCreated            = "2023-02-24T23:36:38Z"
+	vectorHashData           = "bea7b7acfbad0126b135104024a5f1733e705108f42d59668b05c0c50004c6b0" +
+		"517744132ae165a5349155bef0bb0cf2258fff99dfe1dbd914b938d775a36017"
+	vectorProofValue = "z2YwC8z3ap7yx1nZYCg4L3j3ApHsF8kgPdSb5xoS1VR7vPG3F561B52hYnQF9iseabecm3ijx4K1FBTQsCZahKZme"
+
+	multicodecHeaderLen = 2
+	fooBar              = "foo bar"
+)
+
+func TestNew(t *testing.T) {
+	docLoader := createDocumentLoader(t)
+
+	t.Run("signer success", func(t *testing.T) {
+		sigInit := NewSignerInitializer(&SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter:     WithStaticSigner(&mockSigner{}),
+		})
+
+		require.Equal(t, SuiteType, sigInit.Type())
+
+		signer, err := sigInit.Signer()
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+		require.False(t, signer.RequiresCreated())
+	})
+
+	t.Run("verifier success", func(t *testing.T) {
+		verInit := NewVerifierInitializer(&VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		})
+
+		require.Equal(t, SuiteType, verInit.Type())
+
+		verifier, err := verInit.Verifier()
+		require.NoError(t, err)
+		require.NotNil(t, verifier)
+		require.False(t, verifier.RequiresCreated())
+	})
+}
+
+func TestSuite_W3CTestVector(t *testing.T) {
+	_, secretKey, err := multibase.Decode(vectorSecretKeyMultibase)
+	require.NoError(t, err)
+
+	_, publicKey, err := multibase.Decode(vectorPublicKeyMultibase)
+	require.NoError(t, err)
+
+	priv := ed25519.NewKeyFromSeed(secretKey[multicodecHeaderLen:])
+	pub := ed25519.PublicKey(publicKey[multicodecHeaderLen:])
+	require.Equal(t, pub, priv.Public())
+
+	const vmID = "did:key:" + vectorPublicKeyMultibase + "#" + vectorPublicKeyMultibase
+
+	vm := getVM(t, vmID, pub)
+
+	created, err := time.Parse(models.DateTimeFormat, vectorCreated)
+	require.NoError(t, err)
+
+	docLoader := createDocumentLoader(t)
+
+	s := &Suite{
+		ldLoader:     docLoader,
+		signerGetter: WithStaticSigner(signatureutil.GetEd25519Signer(priv, pub)),
+	}
+
+	opts := &models.ProofOptions{
+		VerificationMethod:   vm,
+		VerificationMethodID: vmID,
+		SuiteType:            SuiteType,
+		Purpose:              "assertionMethod",
+		ProofType:            models.DataIntegrityProof,
+		Created:              created,
+	}
+
+	t.Run("hash data", func(t *testing.T) {
+		hashData, _, err := s.transformAndHash(alumniCredential, &models.Proof{
+			VerificationMethod: vmID,
+			ProofPurpose:       "assertionMethod",
+			Created:            vectorCreated,
+		}, opts)
+		require.NoError(t, err)
+		require.Equal(t, vectorHashData, hex.EncodeToString(hashData))
+	})
+
+	t.Run("create proof", func(t *testing.T) {
+		proof, err := s.CreateProof(alumniCredential, opts)
+		require.NoError(t, err)
+		require.Equal(t, models.DataIntegrityProof, proof.Type)
+		require.Equal(t, SuiteType, proof.CryptoSuite)
+		require.Equal(t, vmID, proof.VerificationMethod)
+		require.Equal(t, vectorCreated, proof.Created)
+		require.Equal(t, vectorProofValue, proof.ProofValue)
+	})
+
+	t.Run("verify proof", func(t *testing.T) {
+		verifier, err := NewVerifierInitializer(&VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		}).Verifier()
+		require.NoError(t, err)
+
+		err = verifier.VerifyProof(alumniCredential, &models.Proof{
+			Type:               models.DataIntegrityProof,
+			CryptoSuite:        SuiteType,
+			ProofPurpose:       "assertionMethod",
+			VerificationMethod: vmID,
+			Created:            vectorCreated,
+			ProofValue:         vectorProofValue,
+		}, opts)
+		require.NoError(t, err)
+	})
+}
+
+func TestSuite_SignVerify(t *testing.T) {
+	docLoader := createDocumentLoader(t)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	vm := getVM(t, "did:foo:bar#key-1", pub)
+
+	signer, err := NewSignerInitializer(&SignerInitializerOptions{
+		LDDocumentLoader: docLoader,
+		SignerGetter:     WithStaticSigner(signatureutil.GetEd25519Signer(priv, pub)),
+	}).Signer()
+	require.NoError(t, err)
+
+	verifier, err := NewVerifierInitializer(&VerifierInitializerOptions{
+		LDDocumentLoader: docLoader,
+	}).Verifier()
+	require.NoError(t, err)
+
+	proofOpts := func() *models.ProofOptions {
+		return &models.ProofOptions{
+			VerificationMethod:   vm,
+			VerificationMethodID: vm.ID,
+			SuiteType:            SuiteType,
+			Purpose:              "authentication",
+			ProofType:            models.DataIntegrityProof,
+			Domain:               "mock-domain",
+			Challenge:            "mock-challenge",
+			Created:              time.Now(),
+		}
+	}
+
+	proof, err := signer.CreateProof(alumniCredential, proofOpts())
+	require.NoError(t, err)
+	require.Equal(t, "mock-domain", proof.Domain)
+	require.Equal(t, "mock-challenge", proof.Challenge)
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, verifier.VerifyProof(alumniCredential, proof, proofOpts()))
+	})
+
+	t.Run("tampered document", func(t *testing.T) {
+		docData := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(alumniCredential, &docData))
+
+		docData["name"] = "Tampered Credential"
+
+		tampered, err := json.Marshal(docData)
+		require.NoError(t, err)
+
+		err = verifier.VerifyProof(tampered, proof, proofOpts())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify eddsa-rdfc-2022 DI proof")
+	})
+
+	t.Run("tampered proof options", func(t *testing.T) {
+		tamperedProof := *proof
+		tamperedProof.Challenge = "other-challenge"
+
+		err := verifier.VerifyProof(alumniCredential, &tamperedProof, proofOpts())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify eddsa-rdfc-2022 DI proof")
+	})
+}
+
+func TestSuite_CreateProof_Failures(t *testing.T) {
+	docLoader := createDocumentLoader(t)
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	vm := getVM(t, "did:foo:bar#key-1", pub)
+
+	proofOpts := func() *models.ProofOptions {
+		return &models.ProofOptions{
+			VerificationMethod:   vm,
+			VerificationMethodID: vm.ID,
+			SuiteType:            SuiteType,
+			Purpose:              "assertionMethod",
+			ProofType:            models.DataIntegrityProof,
+			Created:              time.Now(),
+		}
+	}
+
+	testSign := func(t *testing.T, signer Signer, doc []byte, opts *models.ProofOptions, errStr string, errIs error) {
+		t.Helper()
+
+		s, err := NewSignerInitializer(&SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter:     WithStaticSigner(signer),
+		}).Signer()
+		require.NoError(t, err)
+
+		proof, err := s.CreateProof(doc, opts)
+		require.Error(t, err)
+		require.Nil(t, proof)
+
+		if errStr != "" {
+			require.Contains(t, err.Error(), errStr)
+		}
+
+		if errIs != nil {
+			require.ErrorIs(t, err, errIs)
+		}
+	}
+
+	t.Run("signer error", func(t *testing.T) {
+		errExpected := errors.New("expected error")
+
+		testSign(t, &mockSigner{err: errExpected}, alumniCredential, proofOpts(), "", errExpected)
+	})
+
+	t.Run("unmarshal doc", func(t *testing.T) {
+		testSign(t, &mockSigner{}, []byte("not JSON!"), proofOpts(), "expects JSON-LD payload", nil)
+	})
+
+	t.Run("no verification method", func(t *testing.T) {
+		opts := proofOpts()
+		opts.VerificationMethod = nil
+
+		testSign(t, &mockSigner{}, alumniCredential, opts, "verification method is required", nil)
+	})
+
+	t.Run("no jwk in vm", func(t *testing.T) {
+		opts := proofOpts()
+		opts.VerificationMethod = &did.VerificationMethod{
+			ID:    vm.ID,
+			Value: []byte(fooBar),
+		}
+
+		testSign(t, &mockSigner{}, alumniCredential, opts, "verification method needs JWK", nil)
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		key, err := jwksupport.JWKFromKey(pub)
+		require.NoError(t, err)
+
+		key.Crv = fooBar
+
+		opts := proofOpts()
+		opts.VerificationMethod, err = did.NewVerificationMethodFromJWK(vm.ID, "JsonWebKey2020", "did:foo:bar", key)
+		require.NoError(t, err)
+
+		testSign(t, &mockSigner{}, alumniCredential, opts, "unsupported EdDSA key", nil)
+	})
+
+	t.Run("invalid proof/suite type", func(t *testing.T) {
+		opts := proofOpts()
+		opts.ProofType = fooBar
+
+		testSign(t, &mockSigner{}, alumniCredential, opts, "", suite.ErrProofTransformation)
+
+		opts = proofOpts()
+		opts.SuiteType = fooBar
+
+		testSign(t, &mockSigner{}, alumniCredential, opts, "", suite.ErrProofTransformation)
+	})
+
+	t.Run("canonicalize doc", func(t *testing.T) {
+		testSign(t, &mockSigner{}, invalidJSONLD, proofOpts(), "canonicalizing signature base data", nil)
+	})
+}
+
+func TestSuite_VerifyProof_Failures(t *testing.T) {
+	docLoader := createDocumentLoader(t)
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	vm := getVM(t, "did:foo:bar#key-1", pub)
+
+	opts := &models.ProofOptions{
+		VerificationMethod:   vm,
+		VerificationMethodID: vm.ID,
+		SuiteType:            SuiteType,
+		Purpose:              "assertionMethod",
+		ProofType:            models.DataIntegrityProof,
+	}
+
+	proof := &models.Proof{
+		Type:               models.DataIntegrityProof,
+		CryptoSuite:        SuiteType,
+		ProofPurpose:       "assertionMethod",
+		VerificationMethod: vm.ID,
+		ProofValue:         "!%^@^@#%&#%#@",
+	}
+
+	t.Run("decode proof signature", func(t *testing.T) {
+		verifier, err := NewVerifierInitializer(&VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		}).Verifier()
+		require.NoError(t, err)
+
+		err = verifier.VerifyProof(alumniCredential, proof, opts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decoding proofValue")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		verifier, err := NewVerifierInitializer(&VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		}).Verifier()
+		require.NoError(t, err)
+
+		badProof := *proof
+
+		badProof.ProofValue, err = multibase.Encode(multibase.Base58BTC, []byte("mock signature"))
+		require.NoError(t, err)
+
+		err = verifier.VerifyProof(alumniCredential, &badProof, opts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify eddsa-rdfc-2022 DI proof")
+	})
+}
+
+type mockSigner struct {
+	err error
+}
+
+func (ms *mockSigner) Sign(_ []byte) ([]byte, error) {
+	if ms.err != nil {
+		return nil, ms.err
+	}
+
+	return []byte("mock signature"), nil
+}
+
+func getVM(t *testing.T, vmID string, pub ed25519.PublicKey) *models.VerificationMethod {
+	t.Helper()
+
+	key, err := jwksupport.JWKFromKey(pub)
+	require.NoError(t, err)
+
+	vm, err := did.NewVerificationMethodFromJWK(vmID, "JsonWebKey2020", "did:foo:bar", key)
+	require.NoError(t, err)
+
+	return vm
+}
+
+func createDocumentLoader(t *testing.T) *documentloader.DocumentLoader {
+	t.Helper()
+
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider(),
+		documentloader.WithExtraContexts(
+			ldcontext.Document{
+				URL:     "https://www.w3.org/ns/credentials/v2",
+				Content: credentialsV2Context,
+			},
+			ldcontext.Document{
+				URL:     "https://www.w3.org/ns/credentials/examples/v2",
+				Content: examplesV2Context,
+			},
+		),
+	)
+	require.NoError(t, err)
+
+	return docLoader
+}
+
+type provider struct {
+	ContextStore        store.ContextStore
+	RemoteProviderStore store.RemoteProviderStore
+}
+
+func (p *provider) JSONLDContextStore() store.ContextStore {
+	return p.ContextStore
+}
+
+func (p *provider) JSONLDRemoteProviderStore() store.RemoteProviderStore {
+	return p.RemoteProviderStore
+}
+
+func createMockProvider() *provider {
+	return &provider{
+		ContextStore:        mockldstore.NewMockContextStore(),
+		RemoteProviderStore: mockldstore.NewMockRemoteProviderStore(),
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/asn1"
 	"errors"
+	"fmt"
+	"math/big"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/trustbloc/kms-go/doc/jose/jwk"
@@ -29,80 +32,106 @@ const (
 	Secp256Alg = "ES256K"
 )
 
+const (
+	compressedEvenPrefix = 0x02
+	compressedOddPrefix  = 0x03
+	uncompressedPrefix   = 0x04
+)
+
+// ErrSignerZeroized is returned when signing with an ECDSASigner whose private key was wiped by Zeroize.
+var ErrSignerZeroized = errors.New("ecdsa signer: private key has been zeroized")
+
+// ECDSASignerOpt is an option for ECDSASigner.
+type ECDSASignerOpt func(signer *ECDSASigner)
+
+// WithDeterministicNonce makes the signer generate the nonce deterministically as described in RFC 6979,
+// so signing the same message with the same key always yields the same signature.
+func WithDeterministicNonce() ECDSASignerOpt {
+	return func(signer *ECDSASigner) {
+		signer.deterministic = true
+	}
+}
+
 // NewECDSAP256Signer creates a new ECDSA P256 signer with generated key.
-func NewECDSAP256Signer() (*ECDSASigner, error) {
+func NewECDSAP256Signer(opts ...ECDSASignerOpt) (*ECDSASigner, error) {
 	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, err
 	}
 
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, P256Alg)
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, P256Alg, opts...)
+}
+
+// NewECDSAP256SignerDeterministic creates a new ECDSA P256 signer with generated key
+// that produces deterministic (RFC 6979) signatures.
+func NewECDSAP256SignerDeterministic() (*ECDSASigner, error) {
+	return NewECDSAP256Signer(WithDeterministicNonce())
 }
 
 // GetECDSAP256Signer creates a new ECDSA P256 signer with passed ECDSA P256 private key.
-func GetECDSAP256Signer(privKey *ecdsa.PrivateKey) (*ECDSASigner, error) {
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, P256Alg)
+func GetECDSAP256Signer(privKey *ecdsa.PrivateKey, opts ...ECDSASignerOpt) (*ECDSASigner, error) {
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, P256Alg, opts...)
 }
 
 // NewECDSAP384Signer creates a new ECDSA P384 signer with generated key.
-func NewECDSAP384Signer() (*ECDSASigner, error) {
+func NewECDSAP384Signer(opts ...ECDSASignerOpt) (*ECDSASigner, error) {
 	privKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
 	if err != nil {
 		return nil, err
 	}
 
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA384, P384Alg)
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA384, P384Alg, opts...)
 }
 
 // GetECDSAP384Signer creates a new ECDSA P384 signer with passed ECDSA P384 private key.
-func GetECDSAP384Signer(privKey *ecdsa.PrivateKey) (*ECDSASigner, error) {
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA384, P384Alg)
+func GetECDSAP384Signer(privKey *ecdsa.PrivateKey, opts ...ECDSASignerOpt) (*ECDSASigner, error) {
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA384, P384Alg, opts...)
 }
 
 // NewECDSAP521Signer creates a new ECDSA P521 signer with generated key.
-func NewECDSAP521Signer() (*ECDSASigner, error) {
+func NewECDSAP521Signer(opts ...ECDSASignerOpt) (*ECDSASigner, error) {
 	privKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
 	if err != nil {
 		return nil, err
 	}
 
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA512, P521Alg)
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA512, P521Alg, opts...)
 }
 
 // GetECDSAP521Signer creates a new ECDSA P521 signer with passed ECDSA P521 private key.
-func GetECDSAP521Signer(privKey *ecdsa.PrivateKey) (*ECDSASigner, error) {
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA512, P521Alg)
+func GetECDSAP521Signer(privKey *ecdsa.PrivateKey, opts ...ECDSASignerOpt) (*ECDSASigner, error) {
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA512, P521Alg, opts...)
 }
 
 // NewECDSASecp256k1Signer creates a new ECDSA Secp256k1 signer with generated key.
-func NewECDSASecp256k1Signer() (*ECDSASigner, error) {
+func NewECDSASecp256k1Signer(opts ...ECDSASignerOpt) (*ECDSASigner, error) {
 	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
 	if err != nil {
 		return nil, err
 	}
 
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, Secp256Alg)
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, Secp256Alg, opts...)
 }
 
 // GetECDSASecp256k1Signer creates a new ECDSA Secp256k1 signer with passed ECDSA Secp256k1 private key.
-func GetECDSASecp256k1Signer(privKey *ecdsa.PrivateKey) (*ECDSASigner, error) {
-	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, Secp256Alg)
+func GetECDSASecp256k1Signer(privKey *ecdsa.PrivateKey, opts ...ECDSASignerOpt) (*ECDSASigner, error) {
+	return newECDSASigner(privKey, &privKey.PublicKey, crypto.SHA256, Secp256Alg, opts...)
 }
 
 // NewECDSASigner creates a new ECDSA signer based on the input elliptic curve.
-func NewECDSASigner(curve elliptic.Curve) (*ECDSASigner, error) {
+func NewECDSASigner(curve elliptic.Curve, opts ...ECDSASignerOpt) (*ECDSASigner, error) {
 	switch curve {
 	case elliptic.P256():
-		return NewECDSAP256Signer()
+		return NewECDSAP256Signer(opts...)
 
 	case elliptic.P384():
-		return NewECDSAP384Signer()
+		return NewECDSAP384Signer(opts...)
 
 	case elliptic.P521():
-		return NewECDSAP521Signer()
+		return NewECDSAP521Signer(opts...)
 
 	case btcec.S256():
-		return NewECDSASecp256k1Signer()
+		return NewECDSASecp256k1Signer(opts...)
 
 	default:
 		return nil, errors.New("unsupported curve")
@@ -110,29 +139,64 @@ func NewECDSASigner(curve elliptic.Curve) (*ECDSASigner, error) {
 }
 
 // GetECDSASigner creates a new ECDSA signer based on the input *ecdsa.PrivateKey.
-func GetECDSASigner(privKey *ecdsa.PrivateKey) (*ECDSASigner, error) {
+func GetECDSASigner(privKey *ecdsa.PrivateKey, opts ...ECDSASignerOpt) (*ECDSASigner, error) {
 	switch privKey.Curve {
 	case elliptic.P256():
-		return GetECDSAP256Signer(privKey)
+		return GetECDSAP256Signer(privKey, opts...)
 	case elliptic.P384():
-		return GetECDSAP384Signer(privKey)
+		return GetECDSAP384Signer(privKey, opts...)
 	case elliptic.P521():
-		return GetECDSAP521Signer(privKey)
+		return GetECDSAP521Signer(privKey, opts...)
 	case btcec.S256():
-		return GetECDSASecp256k1Signer(privKey)
+		return GetECDSASecp256k1Signer(privKey, opts...)
 	default:
 		return nil, errors.New("unsupported curve")
 	}
 }
 
+// ParseECDSAPublicKeyBytes parses an ECDSA public key on the given curve from its SEC1 encoding.
+// Both the compressed (0x02/0x03 prefix) and uncompressed (0x04 prefix) forms are accepted.
+func ParseECDSAPublicKeyBytes(curve elliptic.Curve, data []byte) (*ecdsa.PublicKey, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty public key bytes")
+	}
+
+	if curve == btcec.S256() {
+		pubKey, err := btcec.ParsePubKey(data, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("parse secp256k1 public key: %w", err)
+		}
+
+		return pubKey.ToECDSA(), nil
+	}
+
+	var x, y *big.Int
+
+	switch data[0] {
+	case compressedEvenPrefix, compressedOddPrefix:
+		x, y = elliptic.UnmarshalCompressed(curve, data)
+	case uncompressedPrefix:
+		x, y = elliptic.Unmarshal(curve, data)
+	default:
+		return nil, fmt.Errorf("unsupported public key format prefix: 0x%02x", data[0])
+	}
+
+	if x == nil {
+		return nil, errors.New("invalid public key bytes")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
 // ECDSASigner makes ECDSA based signatures.
 type ECDSASigner struct {
-	privateKey  *ecdsa.PrivateKey
-	PubKey      *ecdsa.PublicKey
-	PubKeyJWK   *jwk.JWK
-	pubKeyBytes []byte
-	hash        crypto.Hash
-	alg         string
+	privateKey    *ecdsa.PrivateKey
+	PubKey        *ecdsa.PublicKey
+	PubKeyJWK     *jwk.JWK
+	pubKeyBytes   []byte
+	hash          crypto.Hash
+	alg           string
+	deterministic bool
 }
 
 func newECDSASigner(
@@ -140,20 +204,27 @@ func newECDSASigner(
 	pubKey *ecdsa.PublicKey,
 	hash crypto.Hash,
 	alg string,
+	opts ...ECDSASignerOpt,
 ) (*ECDSASigner, error) {
 	pubJWK, err := jwksupport.JWKFromKey(pubKey)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ECDSASigner{
+	signer := &ECDSASigner{
 		privateKey:  privKey,
 		PubKey:      pubKey,
 		PubKeyJWK:   pubJWK,
 		pubKeyBytes: elliptic.Marshal(pubKey.Curve, pubKey.X, pubKey.Y),
 		hash:        hash,
 		alg:         alg,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(signer)
+	}
+
+	return signer, nil
 }
 
 // PublicKey returns a public key object (*ecdsa.PublicKey).
@@ -171,9 +242,74 @@ func (es *ECDSASigner) PublicKeyBytes() []byte {
 	return es.pubKeyBytes
 }
 
+// Zeroize overwrites the private key scalar D with zeros and drops the signer's reference to the private key.
+// Any subsequent signing attempt returns ErrSignerZeroized.
+// Note that the private key passed to a Get*Signer constructor is shared with the signer, so it is wiped as well.
+func (es *ECDSASigner) Zeroize() {
+	if es.privateKey == nil {
+		return
+	}
+
+	if d := es.privateKey.D; d != nil {
+		words := d.Bits()
+		for i := range words {
+			words[i] = 0
+		}
+
+		d.SetInt64(0)
+	}
+
+	es.privateKey = nil
+}
+
 // Sign signs a message.
+// The signature is returned in the IEEE P1363 format (r||s with both values padded to the curve size).
 func (es *ECDSASigner) Sign(msg []byte) ([]byte, error) {
-	return signEcdsa(msg, es.privateKey, es.hash)
+	if es.privateKey == nil {
+		return nil, ErrSignerZeroized
+	}
+
+	r, s, err := signEcdsa(msg, es.privateKey, es.hash, es.deterministic)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalP1363(r, s, es.privateKey.Curve.Params().BitSize), nil
+}
+
+// SignDER signs a message.
+// The signature is returned as an ASN.1 DER encoded sequence of r and s.
+func (es *ECDSASigner) SignDER(msg []byte) ([]byte, error) {
+	if es.privateKey == nil {
+		return nil, ErrSignerZeroized
+	}
+
+	r, s, err := signEcdsa(msg, es.privateKey, es.hash, es.deterministic)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(ecdsaSignature{R: r, S: s})
+}
+
+// SignHashed signs a digest that was already computed with the signer's hash function.
+// The signature is returned in the IEEE P1363 format.
+func (es *ECDSASigner) SignHashed(digest []byte) ([]byte, error) {
+	if es.privateKey == nil {
+		return nil, ErrSignerZeroized
+	}
+
+	if len(digest) != es.hash.Size() {
+		return nil, fmt.Errorf("invalid digest size: expected %d bytes for %s, got %d",
+			es.hash.Size(), es.hash, len(digest))
+	}
+
+	r, s, err := signEcdsaDigest(digest, es.privateKey, es.hash, es.deterministic)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalP1363(r, s, es.privateKey.Curve.Params().BitSize), nil
 }
 
 // Alg return alg.
@@ -181,23 +317,76 @@ func (es *ECDSASigner) Alg() string {
 	return es.alg
 }
 
-//nolint:gomnd
-func signEcdsa(msg []byte, privateKey *ecdsa.PrivateKey, hash crypto.Hash) ([]byte, error) {
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// P1363ToDER converts an IEEE P1363 (r||s) signature created with a key of the given curve bit size
+// into an ASN.1 DER encoded signature.
+func P1363ToDER(signature []byte, curveBits int) ([]byte, error) {
+	keyBytes := curveKeyBytes(curveBits)
+
+	if len(signature) != 2*keyBytes {
+		return nil, fmt.Errorf("invalid P1363 signature size: expected %d, got %d", 2*keyBytes, len(signature))
+	}
+
+	return asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(signature[:keyBytes]),
+		S: new(big.Int).SetBytes(signature[keyBytes:]),
+	})
+}
+
+// DERToP1363 converts an ASN.1 DER encoded signature into an IEEE P1363 (r||s) signature
+// padded for a key of the given curve bit size.
+func DERToP1363(signature []byte, curveBits int) ([]byte, error) {
+	var sig ecdsaSignature
+
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal DER signature: %w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("invalid DER signature: trailing data")
+	}
+
+	keyBytes := curveKeyBytes(curveBits)
+
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || len(sig.R.Bytes()) > keyBytes || len(sig.S.Bytes()) > keyBytes {
+		return nil, errors.New("invalid DER signature: r or s out of range")
+	}
+
+	return marshalP1363(sig.R, sig.S, curveBits), nil
+}
+
+func signEcdsa(
+	msg []byte,
+	privateKey *ecdsa.PrivateKey,
+	hash crypto.Hash,
+	deterministic bool,
+) (*big.Int, *big.Int, error) {
 	hasher := hash.New()
 	_, _ = hasher.Write(msg)
 	hashed := hasher.Sum(nil)
 
-	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hashed)
-	if err != nil {
-		return nil, err
+	return signEcdsaDigest(hashed, privateKey, hash, deterministic)
+}
+
+func signEcdsaDigest(
+	digest []byte,
+	privateKey *ecdsa.PrivateKey,
+	hash crypto.Hash,
+	deterministic bool,
+) (*big.Int, *big.Int, error) {
+	if deterministic {
+		return signEcdsaDeterministic(privateKey, hash, digest)
 	}
 
-	curveBits := privateKey.Curve.Params().BitSize
+	return ecdsa.Sign(rand.Reader, privateKey, digest)
+}
 
-	keyBytes := curveBits / 8
-	if curveBits%8 > 0 {
-		keyBytes++
-	}
+func marshalP1363(r, s *big.Int, curveBits int) []byte {
+	keyBytes := curveKeyBytes(curveBits)
 
 	copyPadded := func(source []byte, size int) []byte {
 		dest := make([]byte, size)
@@ -206,5 +395,15 @@ func signEcdsa(msg []byte, privateKey *ecdsa.PrivateKey, hash crypto.Hash) ([]by
 		return dest
 	}
 
-	return append(copyPadded(r.Bytes(), keyBytes), copyPadded(s.Bytes(), keyBytes)...), nil
+	return append(copyPadded(r.Bytes(), keyBytes), copyPadded(s.Bytes(), keyBytes)...)
+}
+
+//nolint:gomnd
+func curveKeyBytes(curveBits int) int {
+	keyBytes := curveBits / 8
+	if curveBits%8 > 0 {
+		keyBytes++
+	}
+
+	return keyBytes
 }
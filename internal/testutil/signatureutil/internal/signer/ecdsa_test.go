@@ -12,6 +12,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -137,3 +138,216 @@ func TestECDSASigner_Sign(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, signature)
 }
+
+func TestParseECDSAPublicKeyBytes(t *testing.T) {
+	curves := []struct {
+		name  string
+		curve elliptic.Curve
+	}{
+		{"P256", elliptic.P256()},
+		{"P384", elliptic.P384()},
+		{"P521", elliptic.P521()},
+		{"secp256k1", btcec.S256()},
+	}
+
+	for _, tc := range curves {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			prefixes := map[byte]bool{}
+
+			// loop until both compressed prefixes (0x02 and 0x03) have been exercised.
+			for len(prefixes) < 3 {
+				privKey, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+				require.NoError(t, err)
+
+				pubKey := &privKey.PublicKey
+
+				for _, data := range [][]byte{
+					marshalCompressed(pubKey),
+					marshalUncompressed(pubKey),
+				} {
+					parsed, err := ParseECDSAPublicKeyBytes(tc.curve, data)
+					require.NoError(t, err)
+					require.Equal(t, tc.curve, parsed.Curve)
+					require.Zero(t, pubKey.X.Cmp(parsed.X))
+					require.Zero(t, pubKey.Y.Cmp(parsed.Y))
+
+					prefixes[data[0]] = true
+				}
+			}
+
+			require.True(t, prefixes[0x02])
+			require.True(t, prefixes[0x03])
+			require.True(t, prefixes[0x04])
+		})
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		compressed := marshalCompressed(&privKey.PublicKey)
+
+		_, err = ParseECDSAPublicKeyBytes(elliptic.P256(), compressed[:len(compressed)-1])
+		require.EqualError(t, err, "invalid public key bytes")
+
+		_, err = ParseECDSAPublicKeyBytes(elliptic.P256(), marshalUncompressed(&privKey.PublicKey)[:40])
+		require.EqualError(t, err, "invalid public key bytes")
+
+		_, err = ParseECDSAPublicKeyBytes(btcec.S256(), compressed[:20])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse secp256k1 public key")
+
+		_, err = ParseECDSAPublicKeyBytes(elliptic.P256(), []byte{0x05, 0x01})
+		require.EqualError(t, err, "unsupported public key format prefix: 0x05")
+
+		_, err = ParseECDSAPublicKeyBytes(elliptic.P256(), nil)
+		require.EqualError(t, err, "empty public key bytes")
+	})
+}
+
+func marshalCompressed(pubKey *ecdsa.PublicKey) []byte {
+	if pubKey.Curve == btcec.S256() {
+		return (*btcec.PublicKey)(pubKey).SerializeCompressed()
+	}
+
+	return elliptic.MarshalCompressed(pubKey.Curve, pubKey.X, pubKey.Y)
+}
+
+func marshalUncompressed(pubKey *ecdsa.PublicKey) []byte {
+	return elliptic.Marshal(pubKey.Curve, pubKey.X, pubKey.Y)
+}
+
+func TestECDSASigner_SignDER(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521(), btcec.S256()} {
+		curve := curve
+
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			signer, err := NewECDSASigner(curve)
+			require.NoError(t, err)
+
+			msg := []byte("test message")
+
+			hasher := signer.hash.New()
+			_, _ = hasher.Write(msg)
+			hashed := hasher.Sum(nil)
+
+			derSig, err := signer.SignDER(msg)
+			require.NoError(t, err)
+			require.True(t, ecdsa.VerifyASN1(signer.PubKey, hashed, derSig))
+
+			bitSize := curve.Params().BitSize
+
+			p1363Sig, err := DERToP1363(derSig, bitSize)
+			require.NoError(t, err)
+			require.Len(t, p1363Sig, 2*((bitSize+7)/8))
+
+			convertedDER, err := P1363ToDER(p1363Sig, bitSize)
+			require.NoError(t, err)
+			require.Equal(t, derSig, convertedDER)
+
+			p1363Sig, err = signer.Sign(msg)
+			require.NoError(t, err)
+
+			derSig, err = P1363ToDER(p1363Sig, bitSize)
+			require.NoError(t, err)
+			require.True(t, ecdsa.VerifyASN1(signer.PubKey, hashed, derSig))
+
+			convertedP1363, err := DERToP1363(derSig, bitSize)
+			require.NoError(t, err)
+			require.Equal(t, p1363Sig, convertedP1363)
+		})
+	}
+
+	t.Run("invalid signatures", func(t *testing.T) {
+		_, err := P1363ToDER([]byte("too short"), 256)
+		require.EqualError(t, err, "invalid P1363 signature size: expected 64, got 9")
+
+		_, err = DERToP1363([]byte("not DER"), 256)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal DER signature")
+
+		signer, err := NewECDSAP521Signer()
+		require.NoError(t, err)
+
+		derSig, err := signer.SignDER([]byte("test message"))
+		require.NoError(t, err)
+
+		_, err = DERToP1363(append(derSig, 0x00), 521)
+		require.EqualError(t, err, "invalid DER signature: trailing data")
+
+		_, err = DERToP1363(derSig, 256)
+		require.EqualError(t, err, "invalid DER signature: r or s out of range")
+	})
+}
+
+func TestECDSASigner_SignHashed(t *testing.T) {
+	signer, err := NewECDSAP384Signer()
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	hasher := crypto.SHA384.New()
+	_, _ = hasher.Write(msg)
+	digest := hasher.Sum(nil)
+
+	signature, err := signer.SignHashed(digest)
+	require.NoError(t, err)
+	require.Len(t, signature, 96)
+
+	r := new(big.Int).SetBytes(signature[:48])
+	s := new(big.Int).SetBytes(signature[48:])
+	require.True(t, ecdsa.Verify(signer.PubKey, digest, r, s))
+
+	t.Run("matches Sign for deterministic signer", func(t *testing.T) {
+		detSigner, err := GetECDSAP384Signer(signer.privateKey, WithDeterministicNonce())
+		require.NoError(t, err)
+
+		hashedSig, err := detSigner.SignHashed(digest)
+		require.NoError(t, err)
+
+		msgSig, err := detSigner.Sign(msg)
+		require.NoError(t, err)
+
+		require.Equal(t, msgSig, hashedSig)
+	})
+
+	t.Run("invalid digest size", func(t *testing.T) {
+		signature, err := signer.SignHashed(digest[:32])
+		require.EqualError(t, err, "invalid digest size: expected 48 bytes for SHA-384, got 32")
+		require.Nil(t, signature)
+	})
+}
+
+func TestECDSASigner_Zeroize(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := GetECDSAP256Signer(privKey)
+	require.NoError(t, err)
+
+	_, err = signer.Sign([]byte("test message"))
+	require.NoError(t, err)
+
+	signer.Zeroize()
+
+	require.Nil(t, signer.privateKey)
+	require.Zero(t, privKey.D.Sign())
+
+	signature, err := signer.Sign([]byte("test message"))
+	require.ErrorIs(t, err, ErrSignerZeroized)
+	require.Nil(t, signature)
+
+	signature, err = signer.SignDER([]byte("test message"))
+	require.ErrorIs(t, err, ErrSignerZeroized)
+	require.Nil(t, signature)
+
+	signature, err = signer.SignHashed(make([]byte, crypto.SHA256.Size()))
+	require.ErrorIs(t, err, ErrSignerZeroized)
+	require.Nil(t, signature)
+
+	// public key material is still available and zeroizing again is a no-op.
+	require.NotNil(t, signer.PublicJWK())
+	require.NotPanics(t, signer.Zeroize)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"errors"
+	"math/big"
+)
+
+// signEcdsaDeterministic signs the digest using a deterministic nonce generated as described in RFC 6979.
+func signEcdsaDeterministic(privateKey *ecdsa.PrivateKey, hash crypto.Hash, digest []byte) (*big.Int, *big.Int, error) {
+	n := privateKey.Curve.Params().N
+
+	k := rfc6979Nonce(privateKey.D, n, hash, digest)
+
+	r, _ := privateKey.Curve.ScalarBaseMult(k.Bytes())
+	r.Mod(r, n)
+
+	if r.Sign() == 0 {
+		return nil, nil, errors.New("deterministic ecdsa: invalid nonce")
+	}
+
+	e := bits2int(digest, n.BitLen())
+
+	s := new(big.Int).Mul(privateKey.D, r)
+	s.Add(s, e)
+	s.Mul(s, new(big.Int).ModInverse(k, n))
+	s.Mod(s, n)
+
+	if s.Sign() == 0 {
+		return nil, nil, errors.New("deterministic ecdsa: invalid nonce")
+	}
+
+	return r, s, nil
+}
+
+// rfc6979Nonce generates the nonce k for the private key x and the hashed message h1 (RFC 6979, section 3.2).
+func rfc6979Nonce(x, q *big.Int, hash crypto.Hash, h1 []byte) *big.Int {
+	qlen := q.BitLen()
+	rolen := (qlen + 7) >> 3 //nolint:gomnd
+
+	bx := append(int2octets(x, rolen), bits2octets(h1, q, rolen)...)
+
+	v := bytes.Repeat([]byte{0x01}, hash.Size())
+	k := make([]byte, hash.Size())
+
+	k = hmacSum(hash, k, v, []byte{0x00}, bx)
+	v = hmacSum(hash, k, v)
+	k = hmacSum(hash, k, v, []byte{0x01}, bx)
+	v = hmacSum(hash, k, v)
+
+	for {
+		var t []byte
+
+		for len(t)*8 < qlen {
+			v = hmacSum(hash, k, v)
+			t = append(t, v...)
+		}
+
+		secret := bits2int(t, qlen)
+		if secret.Sign() > 0 && secret.Cmp(q) < 0 {
+			return secret
+		}
+
+		k = hmacSum(hash, k, v, []byte{0x00})
+		v = hmacSum(hash, k, v)
+	}
+}
+
+func hmacSum(hash crypto.Hash, key []byte, data ...[]byte) []byte {
+	mac := hmac.New(hash.New, key)
+
+	for _, d := range data {
+		_, _ = mac.Write(d)
+	}
+
+	return mac.Sum(nil)
+}
+
+func bits2int(in []byte, qlen int) *big.Int {
+	v := new(big.Int).SetBytes(in)
+
+	if vlen := len(in) * 8; vlen > qlen {
+		v.Rsh(v, uint(vlen-qlen))
+	}
+
+	return v
+}
+
+func int2octets(v *big.Int, rolen int) []byte {
+	out := v.Bytes()
+
+	if len(out) < rolen {
+		padded := make([]byte, rolen)
+		copy(padded[rolen-len(out):], out)
+
+		return padded
+	}
+
+	return out[len(out)-rolen:]
+}
+
+func bits2octets(in []byte, q *big.Int, rolen int) []byte {
+	z1 := bits2int(in, q.BitLen())
+
+	z2 := new(big.Int).Sub(z1, q)
+	if z2.Sign() < 0 {
+		return int2octets(z1, rolen)
+	}
+
+	return int2octets(z2, rolen)
+}
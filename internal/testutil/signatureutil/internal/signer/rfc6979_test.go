@@ -0,0 +1,85 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestECDSASigner_Deterministic(t *testing.T) {
+	t.Run("RFC 6979 test vector (P-256, SHA-256)", func(t *testing.T) {
+		d, ok := new(big.Int).SetString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", 16)
+		require.True(t, ok)
+
+		privKey := &ecdsa.PrivateKey{D: d}
+		privKey.Curve = elliptic.P256()
+		privKey.X, privKey.Y = privKey.Curve.ScalarBaseMult(d.Bytes())
+
+		signer, err := GetECDSAP256Signer(privKey, WithDeterministicNonce())
+		require.NoError(t, err)
+
+		signature, err := signer.Sign([]byte("sample"))
+		require.NoError(t, err)
+		require.Equal(t,
+			"efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716"+
+				"f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8",
+			hex.EncodeToString(signature))
+	})
+
+	t.Run("same message yields same signature", func(t *testing.T) {
+		for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521(), btcec.S256()} {
+			signer, err := NewECDSASigner(curve, WithDeterministicNonce())
+			require.NoError(t, err)
+
+			msg := []byte("test message")
+
+			sig1, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			sig2, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			require.Equal(t, sig1, sig2)
+			require.Len(t, sig1, 2*((curve.Params().BitSize+7)/8))
+		}
+	})
+
+	t.Run("deterministic P256 signer", func(t *testing.T) {
+		signer, err := NewECDSAP256SignerDeterministic()
+		require.NoError(t, err)
+		require.True(t, signer.deterministic)
+
+		sig1, err := signer.Sign([]byte("test message"))
+		require.NoError(t, err)
+
+		sig2, err := signer.Sign([]byte("test message"))
+		require.NoError(t, err)
+
+		require.Equal(t, sig1, sig2)
+	})
+
+	t.Run("non-deterministic signer by default", func(t *testing.T) {
+		signer, err := NewECDSAP256Signer()
+		require.NoError(t, err)
+		require.False(t, signer.deterministic)
+
+		sig1, err := signer.Sign([]byte("test message"))
+		require.NoError(t, err)
+
+		sig2, err := signer.Sign([]byte("test message"))
+		require.NoError(t, err)
+
+		require.NotEqual(t, sig1, sig2)
+	})
+}
@@ -8,6 +8,7 @@ package signatureutil
 
 import (
 	"crypto/ed25519"
+	"crypto/rsa"
 	"errors"
 	"testing"
 
@@ -68,3 +69,13 @@ func NewCryptoSigner(kmsCrypto kmscrypto.KMSCrypto, keyType kmsapi.KeyType) (Sig
 func GetEd25519Signer(privKey ed25519.PrivateKey, pubKey ed25519.PublicKey) Signer {
 	return signer.GetEd25519Signer(privKey, pubKey)
 }
+
+// GetRS256Signer returns RS256 Signer with predefined RSA private key.
+func GetRS256Signer(privKey *rsa.PrivateKey) (Signer, error) {
+	return signer.GetRS256Signer(privKey)
+}
+
+// GetPS256Signer returns PS256 Signer with predefined RSA private key.
+func GetPS256Signer(privKey *rsa.PrivateKey) (Signer, error) {
+	return signer.GetPS256Signer(privKey)
+}
@@ -9,6 +9,7 @@ package signatureutil
 import (
 	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/rsa"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -45,3 +46,20 @@ func TestGetEd25519Signer(t *testing.T) {
 	require.NotNil(t, ed25519Signer)
 	require.IsType(t, &signer.Ed25519Signer{}, ed25519Signer)
 }
+
+func TestGetRSASigners(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rs256Signer, err := GetRS256Signer(privKey)
+	require.NoError(t, err)
+	require.IsType(t, &signer.RS256Signer{}, rs256Signer)
+	require.Equal(t, signer.RS256Alg, rs256Signer.Alg())
+	require.Equal(t, &privKey.PublicKey, rs256Signer.PublicJWK().Key)
+
+	ps256Signer, err := GetPS256Signer(privKey)
+	require.NoError(t, err)
+	require.IsType(t, &signer.PS256Signer{}, ps256Signer)
+	require.Equal(t, signer.PS256Alg, ps256Signer.Alg())
+	require.Equal(t, &privKey.PublicKey, ps256Signer.PublicJWK().Key)
+}
@@ -130,6 +130,71 @@ func TestCredential_GenerateBBSSelectiveDisclosure(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, vcVerified)
 
+	t.Run("reveal only two subject claims", func(t *testing.T) {
+		revealTwoJSON := `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/citizenship/v1",
+    "https://w3id.org/security/bbs/v1"
+  ],
+  "type": ["VerifiableCredential", "PermanentResidentCard"],
+  "@explicit": true,
+  "issuer": {},
+  "issuanceDate": {},
+  "credentialSubject": {
+    "@explicit": true,
+    "type": ["PermanentResident", "Person"],
+    "givenName": {},
+    "birthCountry": {}
+  }
+}
+`
+
+		revealTwoDoc, e := jsonutil.ToMap(revealTwoJSON)
+		require.NoError(t, e)
+
+		derivedNonce := []byte("another nonce")
+
+		derivedVC, e := vc.GenerateBBSSelectiveDisclosure(revealTwoDoc, derivedNonce, vcOptions...)
+		require.NoError(t, e)
+		require.Len(t, derivedVC.Proofs, 1)
+		require.Equal(t, "BbsBlsSignatureProof2020", derivedVC.Proofs[0]["type"])
+
+		derivedVCBytes, e := json.Marshal(derivedVC)
+		require.NoError(t, e)
+
+		derivedVCDoc, e := jsonutil.ToMap(derivedVCBytes)
+		require.NoError(t, e)
+
+		subject, ok := derivedVCDoc["credentialSubject"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "JOHN", subject["givenName"])
+		require.Equal(t, "Bahamas", subject["birthCountry"])
+		require.NotContains(t, subject, "familyName")
+		require.NotContains(t, subject, "gender")
+		require.NotContains(t, subject, "birthDate")
+		require.NotContains(t, derivedVCDoc, "identifier")
+
+		derivedVCVerified, e := parseTestCredential(t, derivedVCBytes,
+			WithEmbeddedSignatureSuites(bbsblssignatureproof2020.New(
+				suite.WithCompactProof(),
+				suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(derivedNonce)))),
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")),
+		)
+		require.NoError(t, e)
+		require.NotNil(t, derivedVCVerified)
+
+		// the derived proof is bound to the nonce used for its creation.
+		_, e = parseTestCredential(t, derivedVCBytes,
+			WithEmbeddedSignatureSuites(bbsblssignatureproof2020.New(
+				suite.WithCompactProof(),
+				suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce)))),
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")),
+		)
+		require.Error(t, e)
+	})
+
 	// error cases
 	t.Run("failed generation of selective disclosure", func(t *testing.T) {
 		var (
@@ -10,8 +10,11 @@ import (
 	"crypto"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/trustbloc/kms-go/doc/jose"
+	"github.com/trustbloc/kms-go/doc/jose/jwk"
 
 	"github.com/trustbloc/vc-go/sdjwt/common"
 	"github.com/trustbloc/vc-go/sdjwt/holder"
@@ -126,6 +129,45 @@ func (vc *Credential) MarshalWithDisclosure(opts ...MarshalDisclosureOption) (st
 	return createSDJWTPresentation(vc, options)
 }
 
+// CreateSDJWTPresentation creates a presentation of an SD-JWT credential in combined format, revealing only
+// the disclosures of the given claim names and appending a holder (key) binding JWT signed by holderSigner.
+// The binding JWT carries the audience and nonce of the verifier's challenge.
+//
+// An error is returned if the credential is not an SD-JWT credential or any of the requested disclosures is missing.
+func (vc *Credential) CreateSDJWTPresentation(
+	disclosuresToReveal []string,
+	holderSigner jose.Signer,
+	audience, nonce string,
+) (string, error) {
+	if vc.JWT == "" || vc.SDJWTHashAlg == "" {
+		return "", fmt.Errorf("credential is not an SD-JWT credential")
+	}
+
+	headers, err := unmarshalJWS(vc.JWT, false, nil, &map[string]interface{}{})
+	if err != nil {
+		return "", fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	bindingHeaders := jose.Headers{}
+
+	if typ, ok := headers.Type(); ok && typ == "vc+sd-jwt" {
+		bindingHeaders[jose.HeaderType] = "kb+jwt"
+	}
+
+	return vc.MarshalWithDisclosure(
+		DiscloseGivenRequired(disclosuresToReveal),
+		DisclosureHolderBinding(&holder.BindingInfo{
+			Payload: holder.BindingPayload{
+				Nonce:    nonce,
+				Audience: audience,
+				IssuedAt: jwt.NewNumericDate(time.Now()),
+			},
+			Signer:  holderSigner,
+			Headers: bindingHeaders,
+		}),
+	)
+}
+
 func filterSDJWTVC(vc *Credential, options *marshalDisclosureOpts) (string, error) {
 	disclosureCodes, err := filteredDisclosureCodes(vc.SDJWTDisclosures, options)
 	if err != nil {
@@ -247,9 +289,9 @@ func filterDisclosures(
 		}
 	}
 
-	for _, claim := range reqMap {
+	for name, claim := range reqMap {
 		if claim == nil {
-			return nil, fmt.Errorf("disclosure list missing required claim")
+			return nil, fmt.Errorf("disclosure list missing required claim: %s", name)
 		}
 
 		out = append(out, claim)
@@ -265,6 +307,7 @@ type MakeSDJWTOpts struct {
 	recursiveClaimsObject []string
 	alwaysIncludeObjects  []string
 	nonSDClaims           []string
+	holderPublicKey       *jwk.JWK
 }
 
 // GetNonSDClaims returns nonSDClaims mostly for testing purposes.
@@ -322,6 +365,14 @@ func MakeSDJWTWithNonSelectivelyDisclosableClaims(nonSDClaims []string) MakeSDJW
 	}
 }
 
+// MakeSDJWTWithHolderPublicKey sets the holder public key which is included into the SD-JWT "cnf" claim,
+// so that the holder can prove possession of the key by means of a key binding JWT.
+func MakeSDJWTWithHolderPublicKey(holderPublicKey *jwk.JWK) MakeSDJWTOption {
+	return func(opts *MakeSDJWTOpts) {
+		opts.holderPublicKey = holderPublicKey
+	}
+}
+
 // MakeSDJWT creates an SD-JWT in combined format for issuance, with all fields in credentialSubject converted
 // recursively into selectively-disclosable SD-JWT claims.
 func (vc *Credential) MakeSDJWT(
@@ -419,6 +470,10 @@ func makeSDJWT( //nolint:funlen,gocyclo
 		issuerOptions = append(issuerOptions, issuer.WithHashAlgorithm(opts.hashAlg))
 	}
 
+	if opts.holderPublicKey != nil {
+		issuerOptions = append(issuerOptions, issuer.WithHolderPublicKey(opts.holderPublicKey))
+	}
+
 	sdjwt, err := issuer.NewFromVC(claimMap, headers, signer, issuerOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("creating SD-JWT from VC: %w", err)
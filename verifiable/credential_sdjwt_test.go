@@ -10,8 +10,10 @@ import (
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/go-jose/go-jose/v3/jwt"
@@ -20,11 +22,13 @@ import (
 	"github.com/trustbloc/vc-go/internal/testutil/signatureutil"
 
 	"github.com/trustbloc/kms-go/doc/jose"
+	"github.com/trustbloc/kms-go/doc/jose/jwk/jwksupport"
 	"github.com/trustbloc/kms-go/spi/kms"
 
 	afgojwt "github.com/trustbloc/vc-go/jwt"
 	"github.com/trustbloc/vc-go/sdjwt/common"
 	"github.com/trustbloc/vc-go/sdjwt/holder"
+	sdjwtverifier "github.com/trustbloc/vc-go/sdjwt/verifier"
 )
 
 func TestParseSDJWT(t *testing.T) {
@@ -370,6 +374,45 @@ func TestMakeSDJWT(t *testing.T) {
 			require.NoError(t, err)
 		})
 
+		t.Run("with SD JWT V5 recursive claims and holder public key", func(t *testing.T) {
+			holderSigner := signatureutil.CryptoSigner(t, kms.ECDSAP256TypeIEEEP1363)
+
+			sdjwt, err := vc.MakeSDJWT(
+				afgojwt.NewEd25519Signer(privKey), "did:example:abc123#key-1",
+				MakeSDJWTWithVersion(common.SDJWTVersionV5),
+				MakeSDJWTWithRecursiveClaimsObjects([]string{"degree"}),
+				MakeSDJWTWithHolderPublicKey(holderSigner.PublicJWK()),
+			)
+			require.NoError(t, err)
+
+			parts := strings.Split(sdjwt, common.CombinedFormatSeparator)
+			require.Greater(t, len(parts), 1)
+
+			payload, err := base64.RawURLEncoding.DecodeString(strings.Split(parts[0], ".")[1])
+			require.NoError(t, err)
+
+			var claims map[string]interface{}
+			require.NoError(t, json.Unmarshal(payload, &claims))
+			require.Equal(t, "sha-256", claims["_sd_alg"])
+
+			cnf, ok := claims["cnf"].(map[string]interface{})
+			require.True(t, ok)
+			require.Contains(t, cnf, "jwk")
+
+			parsedVC, err := ParseCredential([]byte(sdjwt), WithPublicKeyFetcher(SingleKey(pubKey, kms.ED25519)))
+			require.NoError(t, err)
+			require.Len(t, parsedVC.SDJWTDisclosures, len(parts)-1)
+
+			var disclosureNames []string
+			for _, d := range parsedVC.SDJWTDisclosures {
+				disclosureNames = append(disclosureNames, d.Name)
+			}
+
+			// "degree" is disclosed as a whole, and its nested claims are disclosable on their own.
+			require.Contains(t, disclosureNames, "degree")
+			require.Contains(t, disclosureNames, "university")
+		})
+
 		t.Run("with hash option", func(t *testing.T) {
 			sdjwt, err := vc.MakeSDJWT(afgojwt.NewEd25519Signer(privKey), "did:example:abc123#key-1",
 				MakeSDJWTWithHash(crypto.SHA512))
@@ -402,6 +445,99 @@ func TestMakeSDJWT(t *testing.T) {
 	})
 }
 
+func TestCreateSDJWTPresentation(t *testing.T) {
+	issuerPubKey, issuerPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderPubKey, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderPublicJWK, err := jwksupport.JWKFromKey(holderPubKey)
+	require.NoError(t, err)
+
+	issuerVerifier, err := afgojwt.NewEd25519Verifier(issuerPubKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		opts     []MakeSDJWTOption
+		disclose []string
+	}{
+		{
+			name:     "SD-JWT V2",
+			opts:     []MakeSDJWTOption{MakeSDJWTWithVersion(common.SDJWTVersionV2)},
+			disclose: []string{"university"},
+		},
+		{
+			name: "SD-JWT V5",
+			opts: []MakeSDJWTOption{
+				MakeSDJWTWithVersion(common.SDJWTVersionV5),
+				MakeSDJWTWithRecursiveClaimsObjects([]string{"degree"}),
+			},
+			disclose: []string{"degree", "university"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			sdjwt, _ := createTestSDJWTCred(t, issuerPrivKey,
+				append(tc.opts, MakeSDJWTWithHolderPublicKey(holderPublicJWK))...)
+
+			vc, err := ParseCredential([]byte(sdjwt), WithPublicKeyFetcher(SingleKey(issuerPubKey, kms.ED25519)))
+			require.NoError(t, err)
+			require.Greater(t, len(vc.SDJWTDisclosures), len(tc.disclose))
+
+			presentation, err := vc.CreateSDJWTPresentation(tc.disclose,
+				afgojwt.NewEd25519Signer(holderPrivKey), "https://verifier.example.com", "nonce-123")
+			require.NoError(t, err)
+
+			cfp := common.ParseCombinedFormatForPresentation(presentation)
+			require.Len(t, cfp.Disclosures, len(tc.disclose))
+			require.NotEmpty(t, cfp.HolderVerification)
+
+			claims, err := sdjwtverifier.Parse(presentation,
+				sdjwtverifier.WithSignatureVerifier(issuerVerifier),
+				sdjwtverifier.WithHolderVerificationRequired(true),
+				sdjwtverifier.WithExpectedAudienceForHolderVerification("https://verifier.example.com"),
+				sdjwtverifier.WithExpectedNonceForHolderVerification("nonce-123"))
+			require.NoError(t, err)
+			require.NotEmpty(t, claims)
+
+			_, err = sdjwtverifier.Parse(presentation,
+				sdjwtverifier.WithSignatureVerifier(issuerVerifier),
+				sdjwtverifier.WithHolderVerificationRequired(true),
+				sdjwtverifier.WithExpectedAudienceForHolderVerification("https://verifier.example.com"),
+				sdjwtverifier.WithExpectedNonceForHolderVerification("another-nonce"))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "nonce value 'nonce-123' does not match")
+		})
+	}
+
+	t.Run("requested disclosure does not exist", func(t *testing.T) {
+		sdjwt, _ := createTestSDJWTCred(t, issuerPrivKey, MakeSDJWTWithHolderPublicKey(holderPublicJWK))
+
+		vc, err := ParseCredential([]byte(sdjwt), WithPublicKeyFetcher(SingleKey(issuerPubKey, kms.ED25519)))
+		require.NoError(t, err)
+
+		presentation, err := vc.CreateSDJWTPresentation([]string{"university", "favourite-animal"},
+			afgojwt.NewEd25519Signer(holderPrivKey), "https://verifier.example.com", "nonce-123")
+		require.EqualError(t, err, "disclosure list missing required claim: favourite-animal")
+		require.Empty(t, presentation)
+	})
+
+	t.Run("not an SD-JWT credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+		require.NoError(t, err)
+
+		presentation, err := vc.CreateSDJWTPresentation([]string{"university"},
+			afgojwt.NewEd25519Signer(holderPrivKey), "https://verifier.example.com", "nonce-123")
+		require.EqualError(t, err, "credential is not an SD-JWT credential")
+		require.Empty(t, presentation)
+	})
+}
+
 func TestOptions(t *testing.T) {
 	opts := []MakeSDJWTOption{
 		MakeSDJWTWithRecursiveClaimsObjects([]string{"aa", "bb"}),
@@ -7,14 +7,18 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	ldprocessor "github.com/trustbloc/did-go/doc/ld/processor"
 	"github.com/trustbloc/did-go/doc/ld/proof"
+	"github.com/trustbloc/kms-go/doc/jose/jwk"
 
 	"github.com/trustbloc/vc-go/signature/signer"
+	"github.com/trustbloc/vc-go/signature/suite"
+	"github.com/trustbloc/vc-go/signature/suite/jsonwebsignature2020"
 	"github.com/trustbloc/vc-go/signature/verifier"
 )
 
@@ -67,6 +71,82 @@ type LinkedDataProofContext struct {
 	CapabilityChain []interface{}
 }
 
+// JWKSigner defines a signer which also exposes its public key as a JWK.
+type JWKSigner interface {
+	Signer
+	// PublicJWK returns a JWK containing the signer's public key.
+	PublicJWK() *jwk.JWK
+}
+
+// NewJSONWebSignature2020Context creates a LinkedDataProofContext for the JsonWebSignature2020 suite.
+// The JWS algorithm is inferred from the signer's public JWK, so the "alg" header of the proof always
+// matches the key type. An error is returned if the signer's Alg() disagrees with the key.
+func NewJSONWebSignature2020Context(jwkSigner JWKSigner) (*LinkedDataProofContext, error) {
+	alg, err := jsonWebSignature2020Alg(jwkSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkedDataProofContext{
+		SignatureType:           jsonWebSignature2020,
+		Suite:                   jsonwebsignature2020.New(suite.WithSigner(&algSigner{Signer: jwkSigner, alg: alg})),
+		SignatureRepresentation: SignatureJWS,
+	}, nil
+}
+
+func jsonWebSignature2020Alg(jwkSigner JWKSigner) (string, error) {
+	pubJWK := jwkSigner.PublicJWK()
+	if pubJWK == nil {
+		return "", errors.New("signer has no public JWK")
+	}
+
+	var algs []JWSAlgorithm
+
+	switch {
+	case pubJWK.Kty == "OKP" && pubJWK.Crv == "Ed25519":
+		algs = []JWSAlgorithm{EdDSA}
+	case pubJWK.Kty == "EC" && pubJWK.Crv == "P-256":
+		algs = []JWSAlgorithm{ECDSASecp256r1}
+	case pubJWK.Kty == "EC" && pubJWK.Crv == "P-384":
+		algs = []JWSAlgorithm{ECDSASecp384r1}
+	case pubJWK.Kty == "EC" && pubJWK.Crv == "P-521":
+		algs = []JWSAlgorithm{ECDSASecp521r1}
+	case pubJWK.Kty == "EC" && pubJWK.Crv == "secp256k1":
+		algs = []JWSAlgorithm{ECDSASecp256k1}
+	case pubJWK.Kty == "RSA":
+		// PS256 is the algorithm required by the suite, RS256 is accepted if the signer explicitly uses it.
+		algs = []JWSAlgorithm{PS256, RS256}
+	default:
+		return "", fmt.Errorf("unsupported JWK for JsonWebSignature2020: kty=%s crv=%s", pubJWK.Kty, pubJWK.Crv)
+	}
+
+	signerAlg := jwkSigner.Alg()
+
+	for _, a := range algs {
+		name, err := a.Name()
+		if err != nil {
+			return "", err
+		}
+
+		if signerAlg == "" || signerAlg == name {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("signer algorithm %s does not match JWK: kty=%s crv=%s",
+		signerAlg, pubJWK.Kty, pubJWK.Crv)
+}
+
+// algSigner overrides the algorithm reported by the wrapped signer.
+type algSigner struct {
+	Signer
+	alg string
+}
+
+func (s *algSigner) Alg() string {
+	return s.alg
+}
+
 func checkLinkedDataProof(jsonldBytes map[string]interface{}, suites []verifier.SignatureSuite,
 	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts) error {
 	documentVerifier, err := verifier.New(&keyResolverAdapter{pubKeyFetcher}, suites...)
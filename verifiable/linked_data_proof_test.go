@@ -8,18 +8,23 @@ package verifiable
 import (
 	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	ldprocessor "github.com/trustbloc/did-go/doc/ld/processor"
+	"github.com/trustbloc/kms-go/doc/jose/jwk"
 	"github.com/trustbloc/kms-go/spi/kms"
 	"github.com/trustbloc/vc-go/internal/testutil/signatureutil"
 
 	"github.com/trustbloc/vc-go/signature/suite"
 	"github.com/trustbloc/vc-go/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/trustbloc/vc-go/signature/suite/ed25519signature2018"
+	"github.com/trustbloc/vc-go/signature/suite/jsonwebsignature2020"
 	"github.com/trustbloc/vc-go/signature/verifier"
 )
 
@@ -189,3 +194,128 @@ func prepareVCWithSecp256k1LDP(t *testing.T, vcJSON string, signer Signer) *Cred
 
 	return vc
 }
+
+func TestNewJSONWebSignature2020Context(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyType kms.KeyType
+		alg     string
+	}{
+		{name: "Ed25519", keyType: kms.ED25519Type, alg: "EdDSA"},
+		{name: "P-256", keyType: kms.ECDSAP256TypeIEEEP1363, alg: "ES256"},
+		{name: "P-384", keyType: kms.ECDSAP384TypeIEEEP1363, alg: "ES384"},
+		{name: "secp256k1", keyType: kms.ECDSASecp256k1TypeIEEEP1363, alg: "ES256K"},
+		{name: "RSA PS256", keyType: kms.RSAPS256Type, alg: "PS256"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			signer := signatureutil.CryptoSigner(t, tc.keyType)
+
+			ldpContext, err := NewJSONWebSignature2020Context(signer)
+			require.NoError(t, err)
+			require.Equal(t, "JsonWebSignature2020", ldpContext.SignatureType)
+			require.Equal(t, SignatureJWS, ldpContext.SignatureRepresentation)
+			require.Equal(t, tc.alg, ldpContext.Suite.Alg())
+
+			ldpContext.VerificationMethod = "did:example:123456#key1"
+
+			vc, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+			require.NoError(t, err)
+
+			err = vc.AddLinkedDataProof(ldpContext, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t)))
+			require.NoError(t, err)
+			require.Len(t, vc.Proofs, 1)
+
+			jws, ok := vc.Proofs[0]["jws"].(string)
+			require.True(t, ok)
+
+			headerBytes, err := base64.RawURLEncoding.DecodeString(strings.Split(jws, ".")[0])
+			require.NoError(t, err)
+
+			var header map[string]interface{}
+			require.NoError(t, json.Unmarshal(headerBytes, &header))
+			require.Equal(t, tc.alg, header["alg"])
+
+			vcBytes, err := json.Marshal(vc)
+			require.NoError(t, err)
+
+			_, err = parseTestCredential(t, vcBytes,
+				WithEmbeddedSignatureSuites(jsonwebsignature2020.New(
+					suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier()))),
+				WithPublicKeyFetcher(SingleJWK(signer.PublicJWK(), "JsonWebKey2020")))
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("RS256 signer", func(t *testing.T) {
+		ldpContext, err := NewJSONWebSignature2020Context(signatureutil.CryptoSigner(t, kms.RSARS256Type))
+		require.NoError(t, err)
+		require.Equal(t, "RS256", ldpContext.Suite.Alg())
+	})
+
+	t.Run("signer with empty alg", func(t *testing.T) {
+		signer := signatureutil.CryptoSigner(t, kms.ECDSAP384TypeIEEEP1363)
+
+		ldpContext, err := NewJSONWebSignature2020Context(&testJWKSigner{
+			JWKSigner: signer,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "ES384", ldpContext.Suite.Alg())
+	})
+
+	t.Run("signer alg does not match JWK", func(t *testing.T) {
+		signer := signatureutil.CryptoSigner(t, kms.ECDSAP256TypeIEEEP1363)
+
+		ldpContext, err := NewJSONWebSignature2020Context(&testJWKSigner{
+			JWKSigner: signer,
+			alg:       "ES384",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signer algorithm ES384 does not match JWK")
+		require.Nil(t, ldpContext)
+	})
+
+	t.Run("signer without JWK", func(t *testing.T) {
+		ldpContext, err := NewJSONWebSignature2020Context(&testJWKSigner{
+			JWKSigner: signatureutil.CryptoSigner(t, kms.ED25519Type),
+			noJWK:     true,
+		})
+		require.EqualError(t, err, "signer has no public JWK")
+		require.Nil(t, ldpContext)
+	})
+
+	t.Run("unsupported JWK", func(t *testing.T) {
+		ldpContext, err := NewJSONWebSignature2020Context(&testJWKSigner{
+			JWKSigner: signatureutil.CryptoSigner(t, kms.ED25519Type),
+			jwk:       &jwk.JWK{Kty: "OKP", Crv: "X25519"},
+		})
+		require.EqualError(t, err, "unsupported JWK for JsonWebSignature2020: kty=OKP crv=X25519")
+		require.Nil(t, ldpContext)
+	})
+}
+
+type testJWKSigner struct {
+	JWKSigner
+	alg   string
+	jwk   *jwk.JWK
+	noJWK bool
+}
+
+func (s *testJWKSigner) Alg() string {
+	return s.alg
+}
+
+func (s *testJWKSigner) PublicJWK() *jwk.JWK {
+	if s.noJWK {
+		return nil
+	}
+
+	if s.jwk != nil {
+		return s.jwk
+	}
+
+	return s.JWKSigner.PublicJWK()
+}